@@ -0,0 +1,355 @@
+package appstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAppParams are the params for CreateApp.
+type CreateAppParams struct {
+	AppID           uuid.UUID
+	OrgID           uuid.UUID
+	AppExtlID       string
+	AppName         string
+	AppDescription  string
+	CreateAppID     uuid.UUID
+	CreateUserID    uuid.NullUUID
+	CreateTimestamp time.Time
+	UpdateAppID     uuid.UUID
+	UpdateUserID    uuid.NullUUID
+	UpdateTimestamp time.Time
+}
+
+const createApp = `
+INSERT INTO app (app_id, org_id, app_extl_id, app_name, app_description,
+	create_app_id, create_user_id, create_timestamp,
+	update_app_id, update_user_id, update_timestamp)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+
+// CreateApp inserts a single app row.
+func (q *Queries) CreateApp(ctx context.Context, arg CreateAppParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, createApp,
+		arg.AppID, arg.OrgID, arg.AppExtlID, arg.AppName, arg.AppDescription,
+		arg.CreateAppID, arg.CreateUserID, arg.CreateTimestamp,
+		arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// UpdateAppParams are the params for UpdateApp.
+type UpdateAppParams struct {
+	AppName         string
+	AppDescription  string
+	UpdateAppID     uuid.UUID
+	UpdateUserID    uuid.NullUUID
+	UpdateTimestamp time.Time
+	AppID           uuid.UUID
+}
+
+const updateApp = `
+UPDATE app
+SET app_name = $1, app_description = $2,
+	update_app_id = $3, update_user_id = $4, update_timestamp = $5
+WHERE app_id = $6 AND deactivated_at IS NULL
+`
+
+// UpdateApp updates a single, active app row.
+func (q *Queries) UpdateApp(ctx context.Context, arg UpdateAppParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, updateApp,
+		arg.AppName, arg.AppDescription,
+		arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp,
+		arg.AppID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// FindAppByExternalIDRow is the row returned by FindAppByExternalID.
+type FindAppByExternalIDRow struct {
+	AppID          uuid.UUID
+	AppExtlID      string
+	AppName        string
+	AppDescription string
+	OrgID          uuid.UUID
+	OrgExtlID      string
+	OrgName        string
+	OrgDescription string
+	OrgKindID      uuid.UUID
+	OrgKindExtlID  string
+	OrgKindDesc    string
+}
+
+const findAppByExternalID = `
+SELECT a.app_id, a.app_extl_id, a.app_name, a.app_description,
+	o.org_id, o.org_extl_id, o.org_name, o.org_description,
+	ok.org_kind_id, ok.org_kind_extl_id, ok.org_kind_desc
+FROM app a
+INNER JOIN org o ON o.org_id = a.org_id
+INNER JOIN org_kind ok ON ok.org_kind_id = o.org_kind_id
+WHERE a.app_extl_id = $1 AND a.deactivated_at IS NULL
+`
+
+// FindAppByExternalID retrieves an active (non-deactivated) app row,
+// joined with its Org and Org Kind, by external ID.
+func (q *Queries) FindAppByExternalID(ctx context.Context, appExtlID string) (FindAppByExternalIDRow, error) {
+	row := q.db.QueryRow(ctx, findAppByExternalID, appExtlID)
+
+	var i FindAppByExternalIDRow
+	err := row.Scan(
+		&i.AppID, &i.AppExtlID, &i.AppName, &i.AppDescription,
+		&i.OrgID, &i.OrgExtlID, &i.OrgName, &i.OrgDescription,
+		&i.OrgKindID, &i.OrgKindExtlID, &i.OrgKindDesc,
+	)
+	return i, err
+}
+
+// FindAppByExternalIDWithAuditRow is the row returned by
+// FindAppByExternalIDWithAudit. Labels is hydrated separately from
+// app_label, since Postgres has no single-row way to return it without a
+// lateral aggregate.
+type FindAppByExternalIDWithAuditRow struct {
+	AppID          uuid.UUID
+	AppExtlID      string
+	AppName        string
+	AppDescription string
+	OrgID          uuid.UUID
+	OrgExtlID      string
+	OrgName        string
+	OrgDescription string
+	OrgKindID      uuid.UUID
+	OrgKindExtlID  string
+	OrgKindDesc    string
+
+	CreateAppID          uuid.UUID
+	CreateAppExtlID      string
+	CreateAppOrgID       uuid.UUID
+	CreateAppName        string
+	CreateAppDescription string
+	CreateUserID         uuid.NullUUID
+	CreateUsername       string
+	CreateUserOrgID      uuid.UUID
+	CreateUserFirstName  string
+	CreateUserLastName   string
+	CreateTimestamp      time.Time
+
+	UpdateAppID          uuid.UUID
+	UpdateAppExtlID      string
+	UpdateAppOrgID       uuid.UUID
+	UpdateAppName        string
+	UpdateAppDescription string
+	UpdateUserID         uuid.NullUUID
+	UpdateUsername       string
+	UpdateUserOrgID      uuid.UUID
+	UpdateUserFirstName  string
+	UpdateUserLastName   string
+	UpdateTimestamp      time.Time
+
+	Labels []AppLabel
+}
+
+const findAppByExternalIDWithAudit = `
+SELECT a.app_id, a.app_extl_id, a.app_name, a.app_description,
+	o.org_id, o.org_extl_id, o.org_name, o.org_description,
+	ok.org_kind_id, ok.org_kind_extl_id, ok.org_kind_desc,
+	ca.app_id, ca.app_extl_id, ca.org_id, ca.app_name, ca.app_description,
+	cu.user_id, cu.username, cu.org_id, cu.first_name, cu.last_name, a.create_timestamp,
+	ua.app_id, ua.app_extl_id, ua.org_id, ua.app_name, ua.app_description,
+	uu.user_id, uu.username, uu.org_id, uu.first_name, uu.last_name, a.update_timestamp
+FROM app a
+INNER JOIN org o ON o.org_id = a.org_id
+INNER JOIN org_kind ok ON ok.org_kind_id = o.org_kind_id
+INNER JOIN app ca ON ca.app_id = a.create_app_id
+LEFT JOIN person cu ON cu.user_id = a.create_user_id
+INNER JOIN app ua ON ua.app_id = a.update_app_id
+LEFT JOIN person uu ON uu.user_id = a.update_user_id
+WHERE a.app_extl_id = $1 AND a.deactivated_at IS NULL
+`
+
+// FindAppByExternalIDWithAudit retrieves an active (non-deactivated) app
+// row, along with its create/update lineage and Labels, by external ID.
+func (q *Queries) FindAppByExternalIDWithAudit(ctx context.Context, appExtlID string) (FindAppByExternalIDWithAuditRow, error) {
+	row := q.db.QueryRow(ctx, findAppByExternalIDWithAudit, appExtlID)
+
+	var i FindAppByExternalIDWithAuditRow
+	err := row.Scan(
+		&i.AppID, &i.AppExtlID, &i.AppName, &i.AppDescription,
+		&i.OrgID, &i.OrgExtlID, &i.OrgName, &i.OrgDescription,
+		&i.OrgKindID, &i.OrgKindExtlID, &i.OrgKindDesc,
+		&i.CreateAppID, &i.CreateAppExtlID, &i.CreateAppOrgID, &i.CreateAppName, &i.CreateAppDescription,
+		&i.CreateUserID, &i.CreateUsername, &i.CreateUserOrgID, &i.CreateUserFirstName, &i.CreateUserLastName, &i.CreateTimestamp,
+		&i.UpdateAppID, &i.UpdateAppExtlID, &i.UpdateAppOrgID, &i.UpdateAppName, &i.UpdateAppDescription,
+		&i.UpdateUserID, &i.UpdateUsername, &i.UpdateUserOrgID, &i.UpdateUserFirstName, &i.UpdateUserLastName, &i.UpdateTimestamp,
+	)
+	if err != nil {
+		return FindAppByExternalIDWithAuditRow{}, err
+	}
+
+	i.Labels, err = q.FindAppLabelsByAppID(ctx, i.AppID)
+	if err != nil {
+		return FindAppByExternalIDWithAuditRow{}, err
+	}
+
+	return i, nil
+}
+
+// FindAppsByOrgIDRow is a single row returned by FindAppsByOrgID.
+type FindAppsByOrgIDRow struct {
+	AppID          uuid.UUID
+	AppExtlID      string
+	AppName        string
+	AppDescription string
+	Labels         []AppLabel
+}
+
+const findAppsByOrgID = `
+SELECT app_id, app_extl_id, app_name, app_description
+FROM app
+WHERE org_id = $1 AND deactivated_at IS NULL
+ORDER BY app_name
+`
+
+// FindAppsByOrgID returns every active App belonging to orgID, with its
+// Labels hydrated.
+func (q *Queries) FindAppsByOrgID(ctx context.Context, orgID uuid.UUID) ([]FindAppsByOrgIDRow, error) {
+	rows, err := q.db.Query(ctx, findAppsByOrgID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []FindAppsByOrgIDRow
+	for rows.Next() {
+		var i FindAppsByOrgIDRow
+		if err = rows.Scan(&i.AppID, &i.AppExtlID, &i.AppName, &i.AppDescription); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	labelsByAppID, err := q.findAppLabelsByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range items {
+		items[idx].Labels = labelsByAppID[items[idx].AppID]
+	}
+
+	return items, nil
+}
+
+// FindAppByExternalIDIncludeDeactivatedRow is the row returned by
+// FindAppByExternalIDIncludeDeactivated.
+type FindAppByExternalIDIncludeDeactivatedRow struct {
+	AppID               uuid.UUID
+	AppExtlID           string
+	AppName             string
+	AppDescription      string
+	OrgID               uuid.UUID
+	DeactivatedAt       sql.NullTime
+	DeactivatedByAppID  uuid.NullUUID
+	DeactivatedByUserID uuid.NullUUID
+}
+
+const findAppByExternalIDIncludeDeactivated = `
+SELECT app_id, app_extl_id, app_name, app_description, org_id,
+	deactivated_at, deactivated_by_app_id, deactivated_by_user_id
+FROM app
+WHERE app_extl_id = $1
+`
+
+// FindAppByExternalIDIncludeDeactivated retrieves an app row by external
+// ID regardless of deactivation status, so that a soft-deleted App can
+// be located and reactivated.
+func (q *Queries) FindAppByExternalIDIncludeDeactivated(ctx context.Context, appExtlID string) (FindAppByExternalIDIncludeDeactivatedRow, error) {
+	row := q.db.QueryRow(ctx, findAppByExternalIDIncludeDeactivated, appExtlID)
+
+	var i FindAppByExternalIDIncludeDeactivatedRow
+	err := row.Scan(
+		&i.AppID, &i.AppExtlID, &i.AppName, &i.AppDescription, &i.OrgID,
+		&i.DeactivatedAt, &i.DeactivatedByAppID, &i.DeactivatedByUserID,
+	)
+	return i, err
+}
+
+// DeactivateAppParams are the params for DeactivateApp.
+type DeactivateAppParams struct {
+	AppID               uuid.UUID
+	DeactivatedByAppID  uuid.UUID
+	DeactivatedByUserID uuid.NullUUID
+	UpdateAppID         uuid.UUID
+	UpdateUserID        uuid.NullUUID
+	UpdateTimestamp     time.Time
+}
+
+const deactivateApp = `
+UPDATE app
+SET deactivated_at = $1, deactivated_by_app_id = $2, deactivated_by_user_id = $3,
+	update_app_id = $4, update_user_id = $5, update_timestamp = $6
+WHERE app_id = $7 AND deactivated_at IS NULL
+`
+
+// DeactivateApp soft-deletes a single, currently-active app row.
+func (q *Queries) DeactivateApp(ctx context.Context, arg DeactivateAppParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, deactivateApp,
+		arg.UpdateTimestamp, arg.DeactivatedByAppID, arg.DeactivatedByUserID,
+		arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp,
+		arg.AppID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// ReactivateAppParams are the params for ReactivateApp.
+type ReactivateAppParams struct {
+	AppID           uuid.UUID
+	UpdateAppID     uuid.UUID
+	UpdateUserID    uuid.NullUUID
+	UpdateTimestamp time.Time
+}
+
+const reactivateApp = `
+UPDATE app
+SET deactivated_at = NULL, deactivated_by_app_id = NULL, deactivated_by_user_id = NULL,
+	update_app_id = $1, update_user_id = $2, update_timestamp = $3
+WHERE app_id = $4 AND deactivated_at IS NOT NULL
+`
+
+// ReactivateApp clears the deactivated_at/by fields on a previously
+// deactivated app row.
+func (q *Queries) ReactivateApp(ctx context.Context, arg ReactivateAppParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, reactivateApp,
+		arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp,
+		arg.AppID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+const purgeDeactivatedApps = `
+DELETE FROM app
+WHERE deactivated_at IS NOT NULL AND deactivated_at < $1
+`
+
+// PurgeDeactivatedApps permanently deletes every app row deactivated
+// before cutoff. Its app_api_key rows are removed by the
+// ON DELETE CASCADE on app_api_key.app_id.
+func (q *Queries) PurgeDeactivatedApps(ctx context.Context, cutoff time.Time) (int64, error) {
+	ct, err := q.db.Exec(ctx, purgeDeactivatedApps, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
@@ -0,0 +1,198 @@
+package appstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAppAPIKeyParams are the params for CreateAppAPIKey. Only the
+// bcrypt hash of the key's secret is persisted; ApiKeyID is a short,
+// non-secret prefix used to look the row up before comparing the hash.
+type CreateAppAPIKeyParams struct {
+	ApiKeyID        string
+	ApiKeyHash      []byte
+	AppID           uuid.UUID
+	DeactvDate      time.Time
+	CreateAppID     uuid.UUID
+	CreateUserID    uuid.NullUUID
+	CreateTimestamp time.Time
+	UpdateAppID     uuid.UUID
+	UpdateUserID    uuid.NullUUID
+	UpdateTimestamp time.Time
+}
+
+const createAppAPIKey = `
+INSERT INTO app_api_key (api_key_id, api_key_hash, app_id, deactv_date,
+	create_app_id, create_user_id, create_timestamp,
+	update_app_id, update_user_id, update_timestamp)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+// CreateAppAPIKey inserts a single app_api_key row.
+func (q *Queries) CreateAppAPIKey(ctx context.Context, arg CreateAppAPIKeyParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, createAppAPIKey,
+		arg.ApiKeyID, arg.ApiKeyHash, arg.AppID, arg.DeactvDate,
+		arg.CreateAppID, arg.CreateUserID, arg.CreateTimestamp,
+		arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// RevokeAppAPIKeyParams are the params for RevokeAppAPIKey.
+type RevokeAppAPIKeyParams struct {
+	AppID            uuid.UUID
+	ApiKeyID         string
+	RevokedTimestamp time.Time
+	UpdateAppID      uuid.UUID
+	UpdateUserID     uuid.NullUUID
+	UpdateTimestamp  time.Time
+}
+
+const revokeAppAPIKey = `
+UPDATE app_api_key
+SET revoked_timestamp = $1, update_app_id = $2, update_user_id = $3, update_timestamp = $4
+WHERE app_id = $5 AND api_key_id = $6 AND revoked_timestamp IS NULL
+`
+
+// RevokeAppAPIKey immediately invalidates a single, not-yet-revoked
+// app_api_key row.
+func (q *Queries) RevokeAppAPIKey(ctx context.Context, arg RevokeAppAPIKeyParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, revokeAppAPIKey,
+		arg.RevokedTimestamp, arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp,
+		arg.AppID, arg.ApiKeyID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// DeactivateAppAPIKeysExceptParams are the params for
+// DeactivateAppAPIKeysExcept.
+type DeactivateAppAPIKeysExceptParams struct {
+	AppID           uuid.UUID
+	ExceptApiKeyID  string
+	DeactvDate      time.Time
+	UpdateAppID     uuid.UUID
+	UpdateUserID    uuid.NullUUID
+	UpdateTimestamp time.Time
+}
+
+const deactivateAppAPIKeysExcept = `
+UPDATE app_api_key
+SET deactv_date = $1, update_app_id = $2, update_user_id = $3, update_timestamp = $4
+WHERE app_id = $5 AND api_key_id != $6 AND revoked_timestamp IS NULL AND deactv_date > $4
+`
+
+// DeactivateAppAPIKeysExcept schedules every still-active app_api_key
+// row for appID, other than exceptApiKeyID, to expire at DeactvDate.
+func (q *Queries) DeactivateAppAPIKeysExcept(ctx context.Context, arg DeactivateAppAPIKeysExceptParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, deactivateAppAPIKeysExcept,
+		arg.DeactvDate, arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp,
+		arg.AppID, arg.ExceptApiKeyID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// FindAppAPIKeysByAppIDRow is a single row returned by
+// FindAppAPIKeysByAppID.
+type FindAppAPIKeysByAppIDRow struct {
+	ApiKeyID         string
+	DeactvDate       time.Time
+	RevokedTimestamp sql.NullTime
+}
+
+const findAppAPIKeysByAppID = `
+SELECT api_key_id, deactv_date, revoked_timestamp
+FROM app_api_key
+WHERE app_id = $1
+ORDER BY create_timestamp
+`
+
+// FindAppAPIKeysByAppID returns every (redacted) app_api_key row
+// belonging to appID.
+func (q *Queries) FindAppAPIKeysByAppID(ctx context.Context, appID uuid.UUID) ([]FindAppAPIKeysByAppIDRow, error) {
+	rows, err := q.db.Query(ctx, findAppAPIKeysByAppID, appID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []FindAppAPIKeysByAppIDRow
+	for rows.Next() {
+		var i FindAppAPIKeysByAppIDRow
+		if err = rows.Scan(&i.ApiKeyID, &i.DeactvDate, &i.RevokedTimestamp); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// DeactivateAppAPIKeysParams are the params for DeactivateAppAPIKeys.
+type DeactivateAppAPIKeysParams struct {
+	AppID               uuid.UUID
+	DeactivatedByAppID  uuid.UUID
+	DeactivatedByUserID uuid.NullUUID
+	UpdateAppID         uuid.UUID
+	UpdateUserID        uuid.NullUUID
+	UpdateTimestamp     time.Time
+}
+
+const deactivateAppAPIKeys = `
+UPDATE app_api_key
+SET deactivated_at = $1, deactivated_by_app_id = $2, deactivated_by_user_id = $3,
+	update_app_id = $4, update_user_id = $5, update_timestamp = $6
+WHERE app_id = $7 AND deactivated_at IS NULL
+`
+
+// DeactivateAppAPIKeys soft-deletes every currently-active app_api_key
+// row belonging to appID, as part of soft-deleting the App itself.
+func (q *Queries) DeactivateAppAPIKeys(ctx context.Context, arg DeactivateAppAPIKeysParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, deactivateAppAPIKeys,
+		arg.UpdateTimestamp, arg.DeactivatedByAppID, arg.DeactivatedByUserID,
+		arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp,
+		arg.AppID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// ReactivateAppAPIKeysParams are the params for ReactivateAppAPIKeys.
+type ReactivateAppAPIKeysParams struct {
+	AppID           uuid.UUID
+	UpdateAppID     uuid.UUID
+	UpdateUserID    uuid.NullUUID
+	UpdateTimestamp time.Time
+}
+
+const reactivateAppAPIKeys = `
+UPDATE app_api_key
+SET deactivated_at = NULL, deactivated_by_app_id = NULL, deactivated_by_user_id = NULL,
+	update_app_id = $1, update_user_id = $2, update_timestamp = $3
+WHERE app_id = $4 AND deactivated_at IS NOT NULL
+`
+
+// ReactivateAppAPIKeys clears the deactivated_at/by fields on every
+// app_api_key row belonging to appID that was deactivated alongside its
+// App.
+func (q *Queries) ReactivateAppAPIKeys(ctx context.Context, arg ReactivateAppAPIKeysParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, reactivateAppAPIKeys,
+		arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp,
+		arg.AppID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
@@ -0,0 +1,129 @@
+package appstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppLabel is a single app_label row.
+type AppLabel struct {
+	AppID            uuid.UUID
+	LabelKey         string
+	LabelValue       string
+	LabelDescription string
+}
+
+// UpsertAppLabelParams are the params for UpsertAppLabel.
+type UpsertAppLabelParams struct {
+	AppID            uuid.UUID
+	LabelKey         string
+	LabelValue       string
+	LabelDescription string
+	UpdateAppID      uuid.UUID
+	UpdateUserID     uuid.NullUUID
+	UpdateTimestamp  time.Time
+}
+
+const upsertAppLabel = `
+INSERT INTO app_label (app_id, label_key, label_value, label_description,
+	update_app_id, update_user_id, update_timestamp)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (app_id, label_key) DO UPDATE
+SET label_value = $3, label_description = $4,
+	update_app_id = $5, update_user_id = $6, update_timestamp = $7
+`
+
+// UpsertAppLabel creates or updates a single app_label row, keyed on
+// (app_id, label_key).
+func (q *Queries) UpsertAppLabel(ctx context.Context, arg UpsertAppLabelParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, upsertAppLabel,
+		arg.AppID, arg.LabelKey, arg.LabelValue, arg.LabelDescription,
+		arg.UpdateAppID, arg.UpdateUserID, arg.UpdateTimestamp)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// DeleteAppLabelParams are the params for DeleteAppLabel.
+type DeleteAppLabelParams struct {
+	AppID    uuid.UUID
+	LabelKey string
+}
+
+const deleteAppLabel = `
+DELETE FROM app_label WHERE app_id = $1 AND label_key = $2
+`
+
+// DeleteAppLabel removes a single app_label row.
+func (q *Queries) DeleteAppLabel(ctx context.Context, arg DeleteAppLabelParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, deleteAppLabel, arg.AppID, arg.LabelKey)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+const findAppLabelsByAppID = `
+SELECT app_id, label_key, label_value, label_description
+FROM app_label
+WHERE app_id = $1
+ORDER BY label_key
+`
+
+// FindAppLabelsByAppID returns every Label attached to a single App.
+func (q *Queries) FindAppLabelsByAppID(ctx context.Context, appID uuid.UUID) ([]AppLabel, error) {
+	rows, err := q.db.Query(ctx, findAppLabelsByAppID, appID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []AppLabel
+	for rows.Next() {
+		var i AppLabel
+		if err = rows.Scan(&i.AppID, &i.LabelKey, &i.LabelValue, &i.LabelDescription); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+const findAppLabelsByOrgID = `
+SELECT al.app_id, al.label_key, al.label_value, al.label_description
+FROM app_label al
+INNER JOIN app a ON a.app_id = al.app_id
+WHERE a.org_id = $1
+ORDER BY al.app_id, al.label_key
+`
+
+// findAppLabelsByOrgID returns every Label attached to any App in orgID,
+// grouped by AppID, for bulk hydration by FindAppsByOrgID.
+func (q *Queries) findAppLabelsByOrgID(ctx context.Context, orgID uuid.UUID) (map[uuid.UUID][]AppLabel, error) {
+	rows, err := q.db.Query(ctx, findAppLabelsByOrgID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byAppID := make(map[uuid.UUID][]AppLabel)
+	for rows.Next() {
+		var i AppLabel
+		if err = rows.Scan(&i.AppID, &i.LabelKey, &i.LabelValue, &i.LabelDescription); err != nil {
+			return nil, err
+		}
+		byAppID[i.AppID] = append(byAppID[i.AppID], i)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return byAppID, nil
+}
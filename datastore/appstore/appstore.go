@@ -0,0 +1,28 @@
+// Package appstore is the Postgres persistence layer for the app,
+// app_label, and app_api_key tables.
+package appstore
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// DBTX is satisfied by both a pgxpool.Pool and a pgx.Tx, allowing
+// Queries to run either outside or inside an existing transaction.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgx.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Queries wraps a DBTX with the app, app_label, and app_api_key query
+// methods.
+type Queries struct {
+	db DBTX
+}
+
+// New returns a Queries bound to db.
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
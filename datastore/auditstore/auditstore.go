@@ -0,0 +1,27 @@
+// Package auditstore is the Postgres persistence layer for the
+// append-only audit_event table.
+package auditstore
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// DBTX is satisfied by both a pgxpool.Pool and a pgx.Tx, allowing
+// Queries to run either outside or inside an existing transaction.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgx.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Queries wraps a DBTX with the audit_event query methods.
+type Queries struct {
+	db DBTX
+}
+
+// New returns a Queries bound to db.
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
@@ -0,0 +1,80 @@
+package auditstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAuditEventParams are the params for CreateAuditEvent.
+type CreateAuditEventParams struct {
+	EventID   uuid.UUID
+	EventType string
+	AppID     uuid.NullUUID
+	UserID    uuid.NullUUID
+	Moment    time.Time
+	Payload   []byte
+}
+
+const createAuditEvent = `
+INSERT INTO audit_event (event_id, event_type, app_id, user_id, moment, payload)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+// CreateAuditEvent inserts a single append-only audit_event row.
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, createAuditEvent,
+		arg.EventID, arg.EventType, arg.AppID, arg.UserID, arg.Moment, arg.Payload)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// FindAuditEventsByAppIDParams are the params for FindAuditEventsByAppID.
+type FindAuditEventsByAppIDParams struct {
+	AppID  uuid.UUID
+	Limit  int32
+	Offset int32
+}
+
+// AuditEventRow is a single row returned by FindAuditEventsByAppID.
+type AuditEventRow struct {
+	EventID   uuid.UUID
+	EventType string
+	Moment    time.Time
+	Payload   []byte
+}
+
+const findAuditEventsByAppID = `
+SELECT event_id, event_type, moment, payload
+FROM audit_event
+WHERE app_id = $1
+ORDER BY moment ASC
+LIMIT $2 OFFSET $3
+`
+
+// FindAuditEventsByAppID returns an App's audit_event rows in
+// chronological order, paged by Limit/Offset.
+func (q *Queries) FindAuditEventsByAppID(ctx context.Context, arg FindAuditEventsByAppIDParams) ([]AuditEventRow, error) {
+	rows, err := q.db.Query(ctx, findAuditEventsByAppID, arg.AppID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []AuditEventRow
+	for rows.Next() {
+		var i AuditEventRow
+		if err = rows.Scan(&i.EventID, &i.EventType, &i.Moment, &i.Payload); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,46 @@
+package auditstore
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/gilcrest/go-api-basic/domain/audit"
+	"github.com/gilcrest/go-api-basic/domain/errs"
+)
+
+// TxRecorder is an audit.Recorder that writes Events to the audit_event
+// table using Tx, so the write commits (or rolls back) atomically with
+// whatever other statements the caller runs in the same transaction.
+type TxRecorder struct {
+	Tx pgx.Tx
+}
+
+// Record implements audit.Recorder.
+func (r TxRecorder) Record(ctx context.Context, e audit.Event) error {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		return errs.E(errs.Internal, err)
+	}
+
+	params := CreateAuditEventParams{
+		EventID:   e.ID,
+		EventType: string(e.Type),
+		AppID:     uuid.NullUUID{UUID: e.Actor.App.ID, Valid: e.Actor.App.ID != uuid.Nil},
+		UserID:    uuid.NullUUID{UUID: e.Actor.User.ID, Valid: e.Actor.User.ID != uuid.Nil},
+		Moment:    e.Moment,
+		Payload:   payload,
+	}
+
+	rowsAffected, err := New(r.Tx).CreateAuditEvent(ctx, params)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	if rowsAffected != 1 {
+		return errs.E(errs.Database, "audit event rows affected should be 1")
+	}
+
+	return nil
+}
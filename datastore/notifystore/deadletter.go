@@ -0,0 +1,35 @@
+package notifystore
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAppEventDeadLetterParams are the params for CreateAppEventDeadLetter.
+type CreateAppEventDeadLetterParams struct {
+	DeadLetterID   uuid.UUID
+	SubscriptionID uuid.UUID
+	EventType      string
+	Payload        []byte
+	LastError      string
+	Attempts       int32
+	Moment         time.Time
+}
+
+const createAppEventDeadLetter = `
+INSERT INTO app_event_dead_letter (dead_letter_id, subscription_id, event_type, payload, last_error, attempts, moment)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+// CreateAppEventDeadLetter records a delivery that permanently failed
+// after exhausting its retries.
+func (q *Queries) CreateAppEventDeadLetter(ctx context.Context, arg CreateAppEventDeadLetterParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, createAppEventDeadLetter,
+		arg.DeadLetterID, arg.SubscriptionID, arg.EventType, arg.Payload, arg.LastError, arg.Attempts, arg.Moment)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
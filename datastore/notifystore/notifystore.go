@@ -0,0 +1,27 @@
+// Package notifystore is the Postgres persistence layer for webhook
+// subscriptions and their dead-lettered deliveries.
+package notifystore
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// DBTX is satisfied by both a pgxpool.Pool and a pgx.Tx.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgx.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Queries wraps a DBTX with the app_event_subscription and
+// app_event_dead_letter query methods.
+type Queries struct {
+	db DBTX
+}
+
+// New returns a Queries bound to db.
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
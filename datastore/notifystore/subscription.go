@@ -0,0 +1,86 @@
+package notifystore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CreateAppEventSubscriptionParams are the params for CreateAppEventSubscription.
+type CreateAppEventSubscriptionParams struct {
+	SubscriptionID uuid.UUID
+	OrgID          uuid.UUID
+	URL            string
+	Secret         string
+	EventMask      []string
+}
+
+const createAppEventSubscription = `
+INSERT INTO app_event_subscription (subscription_id, org_id, url, secret, event_mask)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+// CreateAppEventSubscription inserts a single app_event_subscription row.
+func (q *Queries) CreateAppEventSubscription(ctx context.Context, arg CreateAppEventSubscriptionParams) (int64, error) {
+	ct, err := q.db.Exec(ctx, createAppEventSubscription,
+		arg.SubscriptionID, arg.OrgID, arg.URL, arg.Secret, strings.Join(arg.EventMask, ","))
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+const deleteAppEventSubscription = `
+DELETE FROM app_event_subscription WHERE subscription_id = $1 AND org_id = $2
+`
+
+// DeleteAppEventSubscription removes a single app_event_subscription row.
+func (q *Queries) DeleteAppEventSubscription(ctx context.Context, subscriptionID, orgID uuid.UUID) (int64, error) {
+	ct, err := q.db.Exec(ctx, deleteAppEventSubscription, subscriptionID, orgID)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
+// AppEventSubscriptionRow is a single row returned by
+// FindAppEventSubscriptionsByOrgID.
+type AppEventSubscriptionRow struct {
+	SubscriptionID uuid.UUID
+	OrgID          uuid.UUID
+	URL            string
+	Secret         string
+	EventMask      string
+}
+
+const findAppEventSubscriptionsByOrgID = `
+SELECT subscription_id, org_id, url, secret, event_mask
+FROM app_event_subscription
+WHERE org_id = $1
+ORDER BY subscription_id
+`
+
+// FindAppEventSubscriptionsByOrgID returns every subscription registered
+// for orgID.
+func (q *Queries) FindAppEventSubscriptionsByOrgID(ctx context.Context, orgID uuid.UUID) ([]AppEventSubscriptionRow, error) {
+	rows, err := q.db.Query(ctx, findAppEventSubscriptionsByOrgID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []AppEventSubscriptionRow
+	for rows.Next() {
+		var i AppEventSubscriptionRow
+		if err = rows.Scan(&i.SubscriptionID, &i.OrgID, &i.URL, &i.Secret, &i.EventMask); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gilcrest/go-api-basic/domain/secure"
+)
+
+// APIKey is a key given to an App which allows it to authenticate
+// against the API. The plaintext secret is never persisted; only a
+// bcrypt hash and a short, non-secret KeyID (used to look up the row
+// before comparing the hash) are stored.
+type APIKey struct {
+	ID               uuid.UUID
+	KeyID            string
+	hash             []byte
+	deactivationDate time.Time
+	revokedDate      time.Time
+}
+
+// IssuedAPIKey pairs a newly minted APIKey with its one-time plaintext
+// Secret. Secret cannot be reconstructed once this value is discarded,
+// so callers must deliver it to the requester immediately.
+type IssuedAPIKey struct {
+	APIKey APIKey
+	Secret string
+}
+
+// NewAPIKey generates a new random secret, hashes it with bcrypt, and
+// returns the resulting APIKey paired with its one-time plaintext
+// Secret.
+func NewAPIKey(r secure.RandomGenerator, deactivationDate time.Time) (IssuedAPIKey, error) {
+	keyID, err := r.RandomString(8)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	secretPart, err := r.RandomString(32)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(secretPart), bcrypt.DefaultCost)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	ak := APIKey{
+		ID:               uuid.New(),
+		KeyID:            keyID,
+		hash:             hash,
+		deactivationDate: deactivationDate,
+	}
+
+	return IssuedAPIKey{APIKey: ak, Secret: keyID + "." + secretPart}, nil
+}
+
+// Hash returns the bcrypt hash of the key's secret, as stored in the database.
+func (ak APIKey) Hash() []byte {
+	return ak.hash
+}
+
+// DeactivationDate returns the date/time the key is no longer valid
+func (ak APIKey) DeactivationDate() time.Time {
+	return ak.deactivationDate
+}
+
+// RevokedDate returns the date/time the key was revoked, or the zero
+// value if it has not been revoked.
+func (ak APIKey) RevokedDate() time.Time {
+	return ak.revokedDate
+}
+
+// Active reports whether the key is neither revoked nor past its
+// deactivation date as of now.
+func (ak APIKey) Active(now time.Time) bool {
+	return ak.revokedDate.IsZero() && ak.deactivationDate.After(now)
+}
+
+// Authenticate splits presented into its KeyID prefix and secret,
+// returning whether it matches this APIKey. The hash comparison is
+// constant-time via bcrypt.CompareHashAndPassword.
+func (ak APIKey) Authenticate(presented string) bool {
+	keyID, secretPart, ok := splitPresentedKey(presented)
+	if !ok || keyID != ak.KeyID {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword(ak.hash, []byte(secretPart)) == nil
+}
+
+// splitPresentedKey splits a presented API key of the form
+// "<keyID>.<secret>" into its two parts.
+func splitPresentedKey(presented string) (keyID, secret string, ok bool) {
+	idx := strings.IndexByte(presented, '.')
+	if idx < 0 {
+		return "", "", false
+	}
+	return presented[:idx], presented[idx+1:], true
+}
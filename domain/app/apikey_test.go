@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeRandomGenerator is a deterministic stand-in for secure.RandomGenerator.
+type fakeRandomGenerator struct{ n int }
+
+func (g *fakeRandomGenerator) RandomString(n int) (string, error) {
+	g.n++
+	return fmt.Sprintf("fake-%d-%0*d", g.n, n, g.n), nil
+}
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	issued, err := NewAPIKey(&fakeRandomGenerator{}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		presented string
+		want      bool
+	}{
+		{name: "correct key", presented: issued.Secret, want: true},
+		{name: "wrong secret, right key ID", presented: issued.APIKey.KeyID + ".wrong-secret", want: false},
+		{name: "unknown key ID", presented: "unknown." + issued.Secret[len(issued.APIKey.KeyID)+1:], want: false},
+		{name: "no separator", presented: "not-a-valid-key", want: false},
+		{name: "empty", presented: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := issued.APIKey.Authenticate(tt.presented); got != tt.want {
+				t.Errorf("Authenticate(%q) = %v, want %v", tt.presented, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitPresentedKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		presented  string
+		wantKeyID  string
+		wantSecret string
+		wantOK     bool
+	}{
+		{name: "valid", presented: "abc123.supersecret", wantKeyID: "abc123", wantSecret: "supersecret", wantOK: true},
+		{name: "secret contains dots", presented: "abc123.super.secret", wantKeyID: "abc123", wantSecret: "super.secret", wantOK: true},
+		{name: "no separator", presented: "abc123supersecret", wantOK: false},
+		{name: "empty", presented: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyID, secretPart, ok := splitPresentedKey(tt.presented)
+			if ok != tt.wantOK {
+				t.Fatalf("splitPresentedKey(%q) ok = %v, want %v", tt.presented, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if keyID != tt.wantKeyID || secretPart != tt.wantSecret {
+				t.Errorf("splitPresentedKey(%q) = (%q, %q), want (%q, %q)", tt.presented, keyID, secretPart, tt.wantKeyID, tt.wantSecret)
+			}
+		})
+	}
+}
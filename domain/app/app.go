@@ -0,0 +1,46 @@
+// Package app holds the App domain model. An App is a service (internal
+// or external) that is granted access to resources in the system through
+// one or more API keys.
+package app
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gilcrest/go-api-basic/domain/org"
+	"github.com/gilcrest/go-api-basic/domain/secure"
+)
+
+// App is a service that interacts with the system
+type App struct {
+	ID          uuid.UUID
+	ExternalID  secure.Identifier
+	Org         org.Org
+	Name        string
+	Description string
+	APIKeys     []APIKey
+	Labels      []Label
+}
+
+// AddNewKey generates a new bcrypt-hashed API key, appends it to the
+// App's APIKeys, deactivating at deactivationDate, and returns the
+// one-time plaintext secret alongside it.
+func (a *App) AddNewKey(r secure.RandomGenerator, deactivationDate time.Time) (IssuedAPIKey, error) {
+	issued, err := NewAPIKey(r, deactivationDate)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+	a.APIKeys = append(a.APIKeys, issued.APIKey)
+	return issued, nil
+}
+
+// Label returns the Label for the given key, if one is set on the App.
+func (a App) Label(key string) (Label, bool) {
+	for _, l := range a.Labels {
+		if l.Key == key {
+			return l, true
+		}
+	}
+	return Label{}, false
+}
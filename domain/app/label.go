@@ -0,0 +1,48 @@
+package app
+
+import (
+	"strings"
+)
+
+// Label is a key/value tag that can be attached to an App for
+// organization and selection (e.g. env=prod, tier=backend). Description
+// is an optional free-form note about why the label was applied.
+type Label struct {
+	Key         string
+	Value       string
+	Description string
+}
+
+// LabelSelector is a set of Label key/value pairs an App must match in
+// order to be included in a List result. A selector with no entries
+// matches every App.
+type LabelSelector map[string]string
+
+// ParseLabelSelector parses a comma-separated list of key=value pairs
+// (e.g. "env=prod,tier=backend") into a LabelSelector.
+func ParseLabelSelector(s string) LabelSelector {
+	sel := make(LabelSelector)
+	if s == "" {
+		return sel
+	}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		sel[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return sel
+}
+
+// Matches reports whether the App's Labels satisfy every key/value pair
+// in the selector.
+func (sel LabelSelector) Matches(a App) bool {
+	for k, v := range sel {
+		l, ok := a.Label(k)
+		if !ok || l.Value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package app
+
+import "testing"
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want LabelSelector
+	}{
+		{name: "empty", in: "", want: LabelSelector{}},
+		{name: "single pair", in: "env=prod", want: LabelSelector{"env": "prod"}},
+		{name: "multiple pairs", in: "env=prod,tier=backend", want: LabelSelector{"env": "prod", "tier": "backend"}},
+		{name: "trims whitespace", in: " env = prod , tier = backend ", want: LabelSelector{"env": "prod", "tier": "backend"}},
+		{name: "skips malformed pair", in: "env=prod,nokv", want: LabelSelector{"env": "prod"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseLabelSelector(tt.in)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseLabelSelector(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("ParseLabelSelector(%q)[%q] = %q, want %q", tt.in, k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	a := App{Labels: []Label{
+		{Key: "env", Value: "prod"},
+		{Key: "tier", Value: "backend"},
+	}}
+
+	tests := []struct {
+		name string
+		sel  LabelSelector
+		want bool
+	}{
+		{name: "empty selector matches everything", sel: LabelSelector{}, want: true},
+		{name: "matching single key", sel: LabelSelector{"env": "prod"}, want: true},
+		{name: "matching all keys", sel: LabelSelector{"env": "prod", "tier": "backend"}, want: true},
+		{name: "mismatched value", sel: LabelSelector{"env": "staging"}, want: false},
+		{name: "missing key", sel: LabelSelector{"region": "us-east-1"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sel.Matches(a); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Package audit holds the types used to record who did what, when, to
+// a resource in the system.
+package audit
+
+import (
+	"time"
+
+	"github.com/gilcrest/go-api-basic/domain/app"
+	"github.com/gilcrest/go-api-basic/domain/user"
+)
+
+// Audit represents a single moment of action taken against a resource,
+// either by an App acting autonomously or by a User acting through an
+// App.
+type Audit struct {
+	App    app.App
+	User   user.User
+	Moment time.Time
+}
+
+// SimpleAudit retains only the first (create) and most recent (update)
+// Audit for a resource. Intermediate history is not retained; see Event
+// and Recorder for the full append-only audit trail.
+type SimpleAudit struct {
+	First Audit
+	Last  Audit
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType identifies the kind of lifecycle event being recorded.
+type EventType string
+
+const (
+	// AppCreated is recorded when a new App is created.
+	AppCreated EventType = "app.created"
+	// AppUpdated is recorded when an App's Name or Description is updated;
+	// its before/after payload also carries the App's Labels. Label-only
+	// changes (AppService.UpsertLabels, DeleteLabel) record no event.
+	AppUpdated EventType = "app.updated"
+	// AppDeleted is recorded when an App is deactivated (soft-deleted).
+	AppDeleted EventType = "app.deleted"
+	// AppReactivated is recorded when a previously deactivated App is
+	// reactivated.
+	AppReactivated EventType = "app.reactivated"
+	// APIKeyIssued is recorded when a new API key is issued for an App.
+	APIKeyIssued EventType = "apikey.issued"
+	// APIKeyRevoked is recorded when an API key is revoked.
+	APIKeyRevoked EventType = "apikey.revoked"
+)
+
+// Event is a single append-only audit record. Unlike SimpleAudit, Events
+// are never overwritten, so the full history of a resource can be
+// reconstructed in order.
+type Event struct {
+	ID      uuid.UUID
+	Type    EventType
+	Actor   Audit
+	Moment  time.Time
+	Payload interface{}
+}
@@ -0,0 +1,24 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogRecorder is a Recorder that writes Events to a structured logger.
+// It never returns an error, since a logging failure should not roll
+// back the caller's transaction.
+type LogRecorder struct {
+	Logger *slog.Logger
+}
+
+// Record implements Recorder.
+func (r LogRecorder) Record(_ context.Context, e Event) error {
+	r.Logger.Info("audit event",
+		slog.String("type", string(e.Type)),
+		slog.Time("moment", e.Moment),
+		slog.String("actor_app_extl_id", e.Actor.App.ExternalID.String()),
+		slog.Any("payload", e.Payload),
+	)
+	return nil
+}
@@ -0,0 +1,28 @@
+package audit
+
+import "context"
+
+// Recorder persists Events. Implementations are expected to be cheap to
+// construct so that a caller can compose several (e.g. a database
+// recorder bound to the current transaction alongside a structured log
+// sink) for a single Record call.
+type Recorder interface {
+	Record(ctx context.Context, e Event) error
+}
+
+// MultiRecorder records an Event to every Recorder in order, stopping
+// and returning the first error encountered.
+type MultiRecorder []Recorder
+
+// Record implements Recorder.
+func (m MultiRecorder) Record(ctx context.Context, e Event) error {
+	for _, r := range m {
+		if r == nil {
+			continue
+		}
+		if err := r.Record(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/gilcrest/go-api-basic/domain/audit"
+)
+
+// Gateway publishes a lifecycle event to every Subscription registered
+// for orgID and evtType. Publish must not block the caller on delivery;
+// implementations are expected to hand the work off to a bounded worker
+// pool and return immediately.
+type Gateway interface {
+	Publish(ctx context.Context, orgID uuid.UUID, evtType audit.EventType, payload interface{})
+}
+
+// NopGateway is a Gateway that discards every event. It behaves the
+// same as leaving AppService.Notifier nil, which AppService also treats
+// as a no-op.
+type NopGateway struct{}
+
+// Publish implements Gateway.
+func (NopGateway) Publish(context.Context, uuid.UUID, audit.EventType, interface{}) {}
@@ -0,0 +1,29 @@
+// Package notify holds the types used to publish App lifecycle events
+// to outbound webhook subscribers.
+package notify
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/gilcrest/go-api-basic/domain/audit"
+)
+
+// Subscription is a single Org's registration to receive webhook
+// deliveries for a set of event types.
+type Subscription struct {
+	ID     uuid.UUID
+	OrgID  uuid.UUID
+	URL    string
+	Secret string
+	Events []audit.EventType
+}
+
+// Wants reports whether the Subscription is registered for evtType.
+func (s Subscription) Wants(evtType audit.EventType) bool {
+	for _, e := range s.Events {
+		if e == evtType {
+			return true
+		}
+	}
+	return false
+}
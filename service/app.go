@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,9 +12,12 @@ import (
 
 	"github.com/gilcrest/go-api-basic/datastore"
 	"github.com/gilcrest/go-api-basic/datastore/appstore"
+	"github.com/gilcrest/go-api-basic/datastore/auditstore"
+	"github.com/gilcrest/go-api-basic/datastore/notifystore"
 	"github.com/gilcrest/go-api-basic/domain/app"
 	"github.com/gilcrest/go-api-basic/domain/audit"
 	"github.com/gilcrest/go-api-basic/domain/errs"
+	"github.com/gilcrest/go-api-basic/domain/notify"
 	"github.com/gilcrest/go-api-basic/domain/org"
 	"github.com/gilcrest/go-api-basic/domain/person"
 	"github.com/gilcrest/go-api-basic/domain/secure"
@@ -27,10 +32,51 @@ type appAudit struct {
 
 // CreateAppRequest is the request struct for Creating an App
 type CreateAppRequest struct {
-	Name        string `json:"name"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Labels      []LabelRequest `json:"labels"`
+}
+
+// LabelRequest is the request struct for a single App Label
+type LabelRequest struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// UpsertLabelsRequest is the request struct for adding or updating one
+// or more Labels on an existing App
+type UpsertLabelsRequest struct {
+	ExternalID string         `json:"external_id"`
+	Labels     []LabelRequest `json:"labels"`
+}
+
+// DeleteLabelRequest is the request struct for removing a single Label
+// from an existing App
+type DeleteLabelRequest struct {
+	ExternalID string `json:"external_id"`
+	Key        string `json:"key"`
+}
+
+// LabelResponse is the response struct for a single App Label
+type LabelResponse struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
 	Description string `json:"description"`
 }
 
+func newLabelResponse(l app.Label) LabelResponse {
+	return LabelResponse{Key: l.Key, Value: l.Value, Description: l.Description}
+}
+
+func newAppLabels(requests []LabelRequest) []app.Label {
+	var labels []app.Label
+	for _, r := range requests {
+		labels = append(labels, app.Label{Key: r.Key, Value: r.Value, Description: r.Description})
+	}
+	return labels
+}
+
 // AppResponse is the response struct for an App
 type AppResponse struct {
 	ExternalID          string           `json:"external_id"`
@@ -47,18 +93,54 @@ type AppResponse struct {
 	UpdateUserLastName  string           `json:"update_user_last_name"`
 	UpdateDateTime      string           `json:"update_date_time"`
 	APIKeys             []APIKeyResponse `json:"api_keys"`
+	Labels              []LabelResponse  `json:"labels"`
+	// IssuedAPIKeys is only populated by Create and carries the one-time
+	// plaintext secret of the key it mints. IssueAPIKey and RotateAPIKeys
+	// return an IssuedAPIKeyResponse directly instead.
+	IssuedAPIKeys []IssuedAPIKeyResponse `json:"issued_api_keys,omitempty"`
 }
 
-// APIKeyResponse is the response fields for an API key
+// APIKeyResponse is the response fields for an API key. The plaintext
+// secret is never included here; it is only ever returned once, at
+// issuance, as an IssuedAPIKeyResponse.
 type APIKeyResponse struct {
-	Key              string `json:"key"`
+	KeyID            string `json:"key_id"`
 	DeactivationDate string `json:"deactivation_date"`
+	Revoked          bool   `json:"revoked"`
 }
 
-// newAPIKeyResponse initializes an APIKeyResponse. The app.APIKey is
-// decrypted and set to the Key field as part of initialization.
+// newAPIKeyResponse initializes an APIKeyResponse from an app.APIKey.
 func newAPIKeyResponse(key app.APIKey) APIKeyResponse {
-	return APIKeyResponse{Key: key.Key(), DeactivationDate: key.DeactivationDate().String()}
+	return APIKeyResponse{
+		KeyID:            key.KeyID,
+		DeactivationDate: key.DeactivationDate().String(),
+		Revoked:          !key.RevokedDate().IsZero(),
+	}
+}
+
+// IssuedAPIKeyResponse is the one-time response for a newly issued or
+// rotated API key. Key is the plaintext secret; after this response it
+// can never be retrieved again, only revoked.
+type IssuedAPIKeyResponse struct {
+	KeyID            string `json:"key_id"`
+	Key              string `json:"key"`
+	DeactivationDate string `json:"deactivation_date"`
+}
+
+// newIssuedAPIKeyResponse initializes an IssuedAPIKeyResponse from an
+// app.IssuedAPIKey.
+func newIssuedAPIKeyResponse(issued app.IssuedAPIKey) IssuedAPIKeyResponse {
+	return IssuedAPIKeyResponse{
+		KeyID:            issued.APIKey.KeyID,
+		Key:              issued.Secret,
+		DeactivationDate: issued.APIKey.DeactivationDate().String(),
+	}
+}
+
+// apiKeyEventPayload is the audit.Event payload recorded for
+// APIKeyIssued and APIKeyRevoked.
+type apiKeyEventPayload struct {
+	KeyID string `json:"key_id"`
 }
 
 // newAppResponse initializes an AppResponse given an app.App
@@ -83,14 +165,76 @@ func newAppResponse(aa appAudit) AppResponse {
 		UpdateUserLastName:  aa.SimpleAudit.Last.User.Profile.LastName,
 		UpdateDateTime:      aa.SimpleAudit.Last.Moment.Format(time.RFC3339),
 		APIKeys:             keys,
+		Labels:              newLabelResponses(aa.App.Labels),
+	}
+}
+
+func newLabelResponses(labels []app.Label) []LabelResponse {
+	var resp []LabelResponse
+	for _, l := range labels {
+		resp = append(resp, newLabelResponse(l))
 	}
+	return resp
 }
 
 // AppService is a service for creating an App
 type AppService struct {
 	Datastorer            Datastorer
 	RandomStringGenerator CryptoRandomGenerator
-	EncryptionKey         *[32]byte
+	// EncryptionKey is no longer used to verify API keys (which are now
+	// bcrypt-hashed); it is retained for callers that still configure it
+	// and may be repurposed to encrypt one-time issuance responses at
+	// rest in a future pending-delivery mechanism.
+	EncryptionKey *[32]byte
+	// AuditRecorder, if set, receives a copy of every audit.Event emitted
+	// by this service in addition to the Postgres audit_event row that
+	// is always written inside the same transaction as the state change.
+	AuditRecorder audit.Recorder
+	// Notifier, if set, delivers lifecycle events to registered webhook
+	// subscribers once the triggering transaction has committed. A nil
+	// Notifier is a no-op.
+	Notifier notify.Gateway
+}
+
+// appSnapshot captures the fields of an App that can change on Update,
+// for use in an AppUpdated audit.Event's before/after payload.
+type appSnapshot struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Labels      []app.Label `json:"labels"`
+}
+
+// appUpdatedPayload is the audit.Event payload recorded for AppUpdated.
+type appUpdatedPayload struct {
+	Before appSnapshot `json:"before"`
+	After  appSnapshot `json:"after"`
+}
+
+// recordAppEvent writes an audit.Event for the given App action inside
+// tx (so it commits atomically with the rest of the caller's
+// transaction) and, if configured, forwards a copy to s.AuditRecorder.
+func (s AppService) recordAppEvent(ctx context.Context, tx pgx.Tx, evtType audit.EventType, payload interface{}, adt audit.Audit) error {
+	e := audit.Event{
+		ID:      uuid.New(),
+		Type:    evtType,
+		Actor:   adt,
+		Moment:  adt.Moment,
+		Payload: payload,
+	}
+
+	rec := audit.MultiRecorder{auditstore.TxRecorder{Tx: tx}, s.AuditRecorder}
+
+	return rec.Record(ctx, e)
+}
+
+// publish forwards an event to s.Notifier, if one is configured. It is
+// always called after the triggering transaction has committed, so a
+// slow or failing subscriber can never roll back the caller's write.
+func (s AppService) publish(ctx context.Context, orgID uuid.UUID, evtType audit.EventType, payload interface{}) {
+	if s.Notifier == nil {
+		return
+	}
+	s.Notifier.Publish(ctx, orgID, evtType, payload)
 }
 
 // Create is used to create an App
@@ -104,9 +248,10 @@ func (s AppService) Create(ctx context.Context, r *CreateAppRequest, adt audit.A
 	a.Org = adt.App.Org
 	a.Name = r.Name
 	a.Description = r.Description
+	a.Labels = newAppLabels(r.Labels)
 
 	keyDeactivation := time.Date(2099, 12, 31, 0, 0, 0, 0, time.UTC)
-	err = a.AddNewKey(s.RandomStringGenerator, s.EncryptionKey, keyDeactivation)
+	issued, err := a.AddNewKey(s.RandomStringGenerator, keyDeactivation)
 	if err != nil {
 		return AppResponse{}, err
 	}
@@ -146,7 +291,8 @@ func (s AppService) Create(ctx context.Context, r *CreateAppRequest, adt audit.A
 	for _, key := range a.APIKeys {
 
 		createAppAPIKeyParams := appstore.CreateAppAPIKeyParams{
-			ApiKey:          key.Ciphertext(),
+			ApiKeyID:        key.KeyID,
+			ApiKeyHash:      key.Hash(),
 			AppID:           a.ID,
 			DeactvDate:      key.DeactivationDate(),
 			CreateAppID:     adt.App.ID,
@@ -170,13 +316,52 @@ func (s AppService) Create(ctx context.Context, r *CreateAppRequest, adt audit.A
 
 	}
 
+	for _, label := range a.Labels {
+		if err = upsertAppLabel(ctx, tx, a.ID, label, adt); err != nil {
+			return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+		}
+	}
+
+	if err = s.recordAppEvent(ctx, tx, audit.AppCreated, appSnapshot{Name: a.Name, Description: a.Description, Labels: a.Labels}, adt); err != nil {
+		return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
 	// commit db txn using pgxpool
 	err = s.Datastorer.CommitTx(ctx, tx)
 	if err != nil {
 		return AppResponse{}, err
 	}
 
-	return newAppResponse(appAudit{App: a, SimpleAudit: audit.SimpleAudit{First: adt, Last: adt}}), nil
+	resp := newAppResponse(appAudit{App: a, SimpleAudit: audit.SimpleAudit{First: adt, Last: adt}})
+	resp.IssuedAPIKeys = []IssuedAPIKeyResponse{newIssuedAPIKeyResponse(issued)}
+
+	s.publish(ctx, a.Org.ID, audit.AppCreated, appSnapshot{Name: a.Name, Description: a.Description, Labels: a.Labels})
+
+	return resp, nil
+}
+
+// upsertAppLabel creates or updates a single app_label row for the given
+// App within the provided transaction.
+func upsertAppLabel(ctx context.Context, tx pgx.Tx, appID uuid.UUID, label app.Label, adt audit.Audit) error {
+	params := appstore.UpsertAppLabelParams{
+		AppID:            appID,
+		LabelKey:         label.Key,
+		LabelValue:       label.Value,
+		LabelDescription: label.Description,
+		UpdateAppID:      adt.App.ID,
+		UpdateUserID:     datastore.NewNullUUID(adt.User.ID),
+		UpdateTimestamp:  adt.Moment,
+	}
+
+	rowsAffected, err := appstore.New(tx).UpsertAppLabel(ctx, params)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	if rowsAffected != 1 {
+		return errs.E(errs.Database, fmt.Sprintf("rows affected should be 1, actual: %d", rowsAffected))
+	}
+
+	return nil
 }
 
 // UpdateAppRequest is the request struct for Updating an App
@@ -203,10 +388,14 @@ func (s AppService) Update(ctx context.Context, r *UpdateAppRequest, adt audit.A
 	// overwrite Last audit with the current audit
 	aa.SimpleAudit.Last = adt
 
+	before := appSnapshot{Name: aa.App.Name, Description: aa.App.Description, Labels: aa.App.Labels}
+
 	// override fields with data from request
 	aa.App.Name = r.Name
 	aa.App.Description = r.Description
 
+	after := appSnapshot{Name: aa.App.Name, Description: aa.App.Description, Labels: aa.App.Labels}
+
 	updateAppParams := appstore.UpdateAppParams{
 		AppName:         aa.App.Name,
 		AppDescription:  aa.App.Description,
@@ -233,17 +422,493 @@ func (s AppService) Update(ctx context.Context, r *UpdateAppRequest, adt audit.A
 		return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, fmt.Sprintf("rows affected should be 1, actual: %d", rowsAffected)))
 	}
 
+	if err = s.recordAppEvent(ctx, tx, audit.AppUpdated, appUpdatedPayload{Before: before, After: after}, adt); err != nil {
+		return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
 	// commit db txn using pgxpool
 	err = s.Datastorer.CommitTx(ctx, tx)
 	if err != nil {
 		return AppResponse{}, err
 	}
 
+	s.publish(ctx, aa.App.Org.ID, audit.AppUpdated, appUpdatedPayload{Before: before, After: after})
+
 	return newAppResponse(aa), nil
 }
 
+// UpsertLabels adds or updates one or more Labels on an existing App.
+// Labels are updated independently of Name/Description and do not
+// affect the App's audit trail.
+func (s AppService) UpsertLabels(ctx context.Context, r *UpsertLabelsRequest, adt audit.Audit) (AppResponse, error) {
+	var err error
+
+	var aa appAudit
+	aa, err = findAppByExternalIDWithAudit(ctx, s.Datastorer.Pool(), r.ExternalID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return AppResponse{}, errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return AppResponse{}, errs.E(errs.Database, err)
+	}
+
+	labels := newAppLabels(r.Labels)
+
+	var tx pgx.Tx
+	tx, err = s.Datastorer.BeginTx(ctx)
+	if err != nil {
+		return AppResponse{}, err
+	}
+
+	for _, label := range labels {
+		if err = upsertAppLabel(ctx, tx, aa.App.ID, label, adt); err != nil {
+			return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+		}
+	}
+
+	err = s.Datastorer.CommitTx(ctx, tx)
+	if err != nil {
+		return AppResponse{}, err
+	}
+
+	aa.App.Labels = mergeLabels(aa.App.Labels, labels)
+
+	return newAppResponse(aa), nil
+}
+
+// mergeLabels overlays updated onto existing, replacing any Label with a
+// matching Key and appending any that are new.
+func mergeLabels(existing, updated []app.Label) []app.Label {
+	merged := make([]app.Label, 0, len(existing)+len(updated))
+	merged = append(merged, existing...)
+	for _, u := range updated {
+		found := false
+		for i, e := range merged {
+			if e.Key == u.Key {
+				merged[i] = u
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, u)
+		}
+	}
+	return merged
+}
+
+// DeleteLabel removes a single Label from an existing App.
+func (s AppService) DeleteLabel(ctx context.Context, r *DeleteLabelRequest) error {
+	a, err := findAppByExternalID(ctx, s.Datastorer.Pool(), r.ExternalID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return errs.E(errs.Database, err)
+	}
+
+	var tx pgx.Tx
+	tx, err = s.Datastorer.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := appstore.New(tx).DeleteAppLabel(ctx, appstore.DeleteAppLabelParams{AppID: a.ID, LabelKey: r.Key})
+	if err != nil {
+		return s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
+	}
+	if rowsAffected != 1 {
+		return s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Validation, "No label exists for the given key"))
+	}
+
+	return s.Datastorer.CommitTx(ctx, tx)
+}
+
+// AppMetaInfoResponse is the response struct for GetAppMetaInfo. It
+// combines the App itself with derived counts and lineage useful for
+// administrative and support tooling.
+type AppMetaInfoResponse struct {
+	App             AppResponse     `json:"app"`
+	OrgKindExtlID   string          `json:"org_kind_extl_id"`
+	ActiveKeyCount  int             `json:"active_key_count"`
+	ExpiredKeyCount int             `json:"expired_key_count"`
+	CreateAppExtlID string          `json:"create_app_extl_id"`
+	Labels          []LabelResponse `json:"labels"`
+}
+
+// GetAppMetaInfo returns an App along with derived metadata: counts of
+// active/expired API keys, the associated Org Kind, creation lineage,
+// and Labels.
+func (s AppService) GetAppMetaInfo(ctx context.Context, extlID string) (AppMetaInfoResponse, error) {
+	aa, err := findAppByExternalIDWithAudit(ctx, s.Datastorer.Pool(), extlID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return AppMetaInfoResponse{}, errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return AppMetaInfoResponse{}, errs.E(errs.Database, err)
+	}
+
+	keyRows, err := appstore.New(s.Datastorer.Pool()).FindAppAPIKeysByAppID(ctx, aa.App.ID)
+	if err != nil {
+		return AppMetaInfoResponse{}, errs.E(errs.Database, err)
+	}
+
+	now := time.Now()
+	var active, expired int
+	for _, row := range keyRows {
+		if row.RevokedTimestamp.Valid || !row.DeactvDate.After(now) {
+			expired++
+		} else {
+			active++
+		}
+	}
+
+	return AppMetaInfoResponse{
+		App:             newAppResponse(aa),
+		OrgKindExtlID:   aa.App.Org.Kind.ExternalID,
+		ActiveKeyCount:  active,
+		ExpiredKeyCount: expired,
+		CreateAppExtlID: aa.SimpleAudit.First.App.ExternalID.String(),
+		Labels:          newLabelResponses(aa.App.Labels),
+	}, nil
+}
+
+// ListAppsRequest is the request struct for listing Apps, optionally
+// filtered by a label selector (e.g. "env=prod,tier=backend").
+type ListAppsRequest struct {
+	LabelSelector string
+}
+
+// AppListItemResponse is the response struct for a single App returned
+// by List. Unlike AppResponse, it omits create/update lineage: List's
+// underlying query returns every App for an Org in one round trip and
+// does not hydrate per-App audit data.
+type AppListItemResponse struct {
+	ExternalID  string          `json:"external_id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Labels      []LabelResponse `json:"labels"`
+}
+
+// newAppListItemResponse initializes an AppListItemResponse from an app.App.
+func newAppListItemResponse(a app.App) AppListItemResponse {
+	return AppListItemResponse{
+		ExternalID:  a.ExternalID.String(),
+		Name:        a.Name,
+		Description: a.Description,
+		Labels:      newLabelResponses(a.Labels),
+	}
+}
+
+// List returns all Apps for the requesting Org, optionally filtered by
+// LabelSelector.
+func (s AppService) List(ctx context.Context, r *ListAppsRequest, adt audit.Audit) ([]AppListItemResponse, error) {
+	sel := app.ParseLabelSelector(r.LabelSelector)
+
+	rows, err := appstore.New(s.Datastorer.Pool()).FindAppsByOrgID(ctx, adt.App.Org.ID)
+	if err != nil {
+		return nil, errs.E(errs.Database, err)
+	}
+
+	var responses []AppListItemResponse
+	for _, row := range rows {
+		a := app.App{
+			ID:          row.AppID,
+			ExternalID:  secure.MustParseIdentifier(row.AppExtlID),
+			Org:         adt.App.Org,
+			Name:        row.AppName,
+			Description: row.AppDescription,
+			Labels:      labelsFromRows(row.Labels),
+		}
+		if !sel.Matches(a) {
+			continue
+		}
+		responses = append(responses, newAppListItemResponse(a))
+	}
+
+	return responses, nil
+}
+
+func labelsFromRows(rows []appstore.AppLabel) []app.Label {
+	var labels []app.Label
+	for _, row := range rows {
+		labels = append(labels, app.Label{Key: row.LabelKey, Value: row.LabelValue, Description: row.LabelDescription})
+	}
+	return labels
+}
+
+// AuditEventResponse is the response struct for a single audit Event
+// returned from History.
+type AuditEventResponse struct {
+	ID      string      `json:"id"`
+	Type    string      `json:"type"`
+	Moment  string      `json:"moment"`
+	Payload interface{} `json:"payload"`
+}
+
+// HistoryRequest is the request struct for paging through an App's
+// audit Event history.
+type HistoryRequest struct {
+	ExternalID string
+	Limit      int32
+	Offset     int32
+}
+
+// History returns the chronological audit Event history for an App, as
+// recorded by every Create/Update/Delete and API key lifecycle call.
+func (s AppService) History(ctx context.Context, r *HistoryRequest) ([]AuditEventResponse, error) {
+	a, err := findAppByExternalID(ctx, s.Datastorer.Pool(), r.ExternalID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return nil, errs.E(errs.Database, err)
+	}
+
+	rows, err := auditstore.New(s.Datastorer.Pool()).FindAuditEventsByAppID(ctx, auditstore.FindAuditEventsByAppIDParams{
+		AppID:  a.ID,
+		Limit:  r.Limit,
+		Offset: r.Offset,
+	})
+	if err != nil {
+		return nil, errs.E(errs.Database, err)
+	}
+
+	var resp []AuditEventResponse
+	for _, row := range rows {
+		var payload interface{}
+		if err = json.Unmarshal(row.Payload, &payload); err != nil {
+			return nil, errs.E(errs.Internal, err)
+		}
+		resp = append(resp, AuditEventResponse{
+			ID:      row.EventID.String(),
+			Type:    row.EventType,
+			Moment:  row.Moment.Format(time.RFC3339),
+			Payload: payload,
+		})
+	}
+
+	return resp, nil
+}
+
+// IssueAPIKeyRequest is the request struct for issuing a new API key
+// for an existing App.
+type IssueAPIKeyRequest struct {
+	ExternalID string
+	TTL        time.Duration
+}
+
+// IssueAPIKey mints a new API key for an existing App. Key in the
+// response is the plaintext secret and is shown exactly once; only its
+// bcrypt hash is persisted.
+func (s AppService) IssueAPIKey(ctx context.Context, r *IssueAPIKeyRequest, adt audit.Audit) (IssuedAPIKeyResponse, error) {
+	a, err := findAppByExternalID(ctx, s.Datastorer.Pool(), r.ExternalID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return IssuedAPIKeyResponse{}, errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return IssuedAPIKeyResponse{}, errs.E(errs.Database, err)
+	}
+
+	issued, err := app.NewAPIKey(s.RandomStringGenerator, adt.Moment.Add(r.TTL))
+	if err != nil {
+		return IssuedAPIKeyResponse{}, err
+	}
+
+	var tx pgx.Tx
+	tx, err = s.Datastorer.BeginTx(ctx)
+	if err != nil {
+		return IssuedAPIKeyResponse{}, err
+	}
+
+	if err = createAppAPIKey(ctx, tx, a.ID, issued.APIKey, adt); err != nil {
+		return IssuedAPIKeyResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
+	if err = s.recordAppEvent(ctx, tx, audit.APIKeyIssued, apiKeyEventPayload{KeyID: issued.APIKey.KeyID}, adt); err != nil {
+		return IssuedAPIKeyResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
+	if err = s.Datastorer.CommitTx(ctx, tx); err != nil {
+		return IssuedAPIKeyResponse{}, err
+	}
+
+	s.publish(ctx, a.Org.ID, audit.APIKeyIssued, apiKeyEventPayload{KeyID: issued.APIKey.KeyID})
+
+	return newIssuedAPIKeyResponse(issued), nil
+}
+
+// createAppAPIKey persists a single app_api_key row for key within tx.
+func createAppAPIKey(ctx context.Context, tx pgx.Tx, appID uuid.UUID, key app.APIKey, adt audit.Audit) error {
+	params := appstore.CreateAppAPIKeyParams{
+		ApiKeyID:        key.KeyID,
+		ApiKeyHash:      key.Hash(),
+		AppID:           appID,
+		DeactvDate:      key.DeactivationDate(),
+		CreateAppID:     adt.App.ID,
+		CreateUserID:    datastore.NewNullUUID(adt.User.ID),
+		CreateTimestamp: adt.Moment,
+		UpdateAppID:     adt.App.ID,
+		UpdateUserID:    datastore.NewNullUUID(adt.User.ID),
+		UpdateTimestamp: adt.Moment,
+	}
+
+	rowsAffected, err := appstore.New(tx).CreateAppAPIKey(ctx, params)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	if rowsAffected != 1 {
+		return errs.E(errs.Database, fmt.Sprintf("rows affected should be 1, actual: %d", rowsAffected))
+	}
+
+	return nil
+}
+
+// RevokeAPIKeyRequest is the request struct for revoking a single API
+// key belonging to an App.
+type RevokeAPIKeyRequest struct {
+	ExternalID string
+	KeyID      string
+}
+
+// RevokeAPIKey immediately invalidates a single API key so it can no
+// longer authenticate, without affecting the App's other keys.
+func (s AppService) RevokeAPIKey(ctx context.Context, r *RevokeAPIKeyRequest, adt audit.Audit) error {
+	a, err := findAppByExternalID(ctx, s.Datastorer.Pool(), r.ExternalID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return errs.E(errs.Database, err)
+	}
+
+	var tx pgx.Tx
+	tx, err = s.Datastorer.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := appstore.New(tx).RevokeAppAPIKey(ctx, appstore.RevokeAppAPIKeyParams{
+		AppID:            a.ID,
+		ApiKeyID:         r.KeyID,
+		RevokedTimestamp: adt.Moment,
+		UpdateAppID:      adt.App.ID,
+		UpdateUserID:     datastore.NewNullUUID(adt.User.ID),
+		UpdateTimestamp:  adt.Moment,
+	})
+	if err != nil {
+		return s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
+	}
+	if rowsAffected != 1 {
+		return s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Validation, "No API key exists for the given key ID"))
+	}
+
+	if err = s.recordAppEvent(ctx, tx, audit.APIKeyRevoked, apiKeyEventPayload{KeyID: r.KeyID}, adt); err != nil {
+		return s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
+	if err = s.Datastorer.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	s.publish(ctx, a.Org.ID, audit.APIKeyRevoked, apiKeyEventPayload{KeyID: r.KeyID})
+
+	return nil
+}
+
+// RotateAPIKeysRequest is the request struct for rotating all of an
+// App's API keys.
+type RotateAPIKeysRequest struct {
+	ExternalID string
+	// Overlap is how long existing, still-active keys continue to work
+	// after the new key is issued, giving callers a window to pick up
+	// the new key before the old ones stop working.
+	Overlap time.Duration
+	TTL     time.Duration
+}
+
+// RotateAPIKeys issues a new API key for an App and schedules every
+// existing, still-active key to deactivate after Overlap.
+func (s AppService) RotateAPIKeys(ctx context.Context, r *RotateAPIKeysRequest, adt audit.Audit) (IssuedAPIKeyResponse, error) {
+	a, err := findAppByExternalID(ctx, s.Datastorer.Pool(), r.ExternalID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return IssuedAPIKeyResponse{}, errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return IssuedAPIKeyResponse{}, errs.E(errs.Database, err)
+	}
+
+	issued, err := app.NewAPIKey(s.RandomStringGenerator, adt.Moment.Add(r.TTL))
+	if err != nil {
+		return IssuedAPIKeyResponse{}, err
+	}
+
+	var tx pgx.Tx
+	tx, err = s.Datastorer.BeginTx(ctx)
+	if err != nil {
+		return IssuedAPIKeyResponse{}, err
+	}
+
+	if err = createAppAPIKey(ctx, tx, a.ID, issued.APIKey, adt); err != nil {
+		return IssuedAPIKeyResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
+	_, err = appstore.New(tx).DeactivateAppAPIKeysExcept(ctx, appstore.DeactivateAppAPIKeysExceptParams{
+		AppID:           a.ID,
+		ExceptApiKeyID:  issued.APIKey.KeyID,
+		DeactvDate:      adt.Moment.Add(r.Overlap),
+		UpdateAppID:     adt.App.ID,
+		UpdateUserID:    datastore.NewNullUUID(adt.User.ID),
+		UpdateTimestamp: adt.Moment,
+	})
+	if err != nil {
+		return IssuedAPIKeyResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
+	}
+
+	if err = s.recordAppEvent(ctx, tx, audit.APIKeyIssued, apiKeyEventPayload{KeyID: issued.APIKey.KeyID}, adt); err != nil {
+		return IssuedAPIKeyResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
+	if err = s.Datastorer.CommitTx(ctx, tx); err != nil {
+		return IssuedAPIKeyResponse{}, err
+	}
+
+	s.publish(ctx, a.Org.ID, audit.APIKeyIssued, apiKeyEventPayload{KeyID: issued.APIKey.KeyID})
+
+	return newIssuedAPIKeyResponse(issued), nil
+}
+
+// ListAPIKeys returns the (redacted) API keys currently associated with
+// an App. Plaintext secrets are never retrievable; see IssueAPIKey and
+// RotateAPIKeys for the one-time issuance responses.
+func (s AppService) ListAPIKeys(ctx context.Context, extlID string) ([]APIKeyResponse, error) {
+	a, err := findAppByExternalID(ctx, s.Datastorer.Pool(), extlID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return nil, errs.E(errs.Database, err)
+	}
+
+	rows, err := appstore.New(s.Datastorer.Pool()).FindAppAPIKeysByAppID(ctx, a.ID)
+	if err != nil {
+		return nil, errs.E(errs.Database, err)
+	}
+
+	var resp []APIKeyResponse
+	for _, row := range rows {
+		resp = append(resp, APIKeyResponse{
+			KeyID:            row.ApiKeyID,
+			DeactivationDate: row.DeactvDate.String(),
+			Revoked:          row.RevokedTimestamp.Valid,
+		})
+	}
+
+	return resp, nil
+}
+
 // Delete is used to delete an App
-func (s AppService) Delete(ctx context.Context, extlID string) (DeleteResponse, error) {
+func (s AppService) Delete(ctx context.Context, extlID string, adt audit.Audit) (DeleteResponse, error) {
 
 	// retrieve existing Org
 	a, err := findAppByExternalID(ctx, s.Datastorer.Pool(), extlID)
@@ -261,10 +926,20 @@ func (s AppService) Delete(ctx context.Context, extlID string) (DeleteResponse,
 		return DeleteResponse{}, err
 	}
 
-	// one-to-many API keys can be associated with an App. This will
-	// delete them all.
+	// Delete is a soft-delete: the App and its API keys are deactivated,
+	// not removed, so the audit trail stays intact. PurgeDeactivated is
+	// the only path to a true, irreversible delete.
+	deactivateAppAPIKeysParams := appstore.DeactivateAppAPIKeysParams{
+		AppID:               a.ID,
+		DeactivatedByAppID:  adt.App.ID,
+		DeactivatedByUserID: datastore.NewNullUUID(adt.User.ID),
+		UpdateAppID:         adt.App.ID,
+		UpdateUserID:        datastore.NewNullUUID(adt.User.ID),
+		UpdateTimestamp:     adt.Moment,
+	}
+
 	var apiKeysRowsAffected int64
-	apiKeysRowsAffected, err = appstore.New(tx).DeleteAppAPIKeys(ctx, a.ID)
+	apiKeysRowsAffected, err = appstore.New(tx).DeactivateAppAPIKeys(ctx, deactivateAppAPIKeysParams)
 	if err != nil {
 		return DeleteResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
 	}
@@ -274,7 +949,14 @@ func (s AppService) Delete(ctx context.Context, extlID string) (DeleteResponse,
 	}
 
 	var rowsAffected int64
-	rowsAffected, err = appstore.New(tx).DeleteApp(ctx, a.ID)
+	rowsAffected, err = appstore.New(tx).DeactivateApp(ctx, appstore.DeactivateAppParams{
+		AppID:               a.ID,
+		DeactivatedByAppID:  adt.App.ID,
+		DeactivatedByUserID: datastore.NewNullUUID(adt.User.ID),
+		UpdateAppID:         adt.App.ID,
+		UpdateUserID:        datastore.NewNullUUID(adt.User.ID),
+		UpdateTimestamp:     adt.Moment,
+	})
 	if err != nil {
 		return DeleteResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
 	}
@@ -283,12 +965,18 @@ func (s AppService) Delete(ctx context.Context, extlID string) (DeleteResponse,
 		return DeleteResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, fmt.Sprintf("rows affected should be 1, actual: %d", rowsAffected)))
 	}
 
+	if err = s.recordAppEvent(ctx, tx, audit.AppDeleted, appSnapshot{Name: a.Name, Description: a.Description, Labels: a.Labels}, adt); err != nil {
+		return DeleteResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
 	// commit db txn using pgxpool
 	err = s.Datastorer.CommitTx(ctx, tx)
 	if err != nil {
 		return DeleteResponse{}, err
 	}
 
+	s.publish(ctx, a.Org.ID, audit.AppDeleted, appSnapshot{Name: a.Name, Description: a.Description, Labels: a.Labels})
+
 	response := DeleteResponse{
 		ExternalID: extlID,
 		Deleted:    true,
@@ -297,8 +985,111 @@ func (s AppService) Delete(ctx context.Context, extlID string) (DeleteResponse,
 	return response, nil
 }
 
+// Reactivate clears the deactivated_at/by fields on a previously
+// deleted App and its API keys, restoring it to active use.
+func (s AppService) Reactivate(ctx context.Context, extlID string, adt audit.Audit) (AppResponse, error) {
+	a, err := appstore.New(s.Datastorer.Pool()).FindAppByExternalIDIncludeDeactivated(ctx, extlID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return AppResponse{}, errs.E(errs.Validation, "No app exists for the given external ID")
+		}
+		return AppResponse{}, errs.E(errs.Database, err)
+	}
+
+	var tx pgx.Tx
+	tx, err = s.Datastorer.BeginTx(ctx)
+	if err != nil {
+		return AppResponse{}, err
+	}
+
+	reactivateParams := appstore.ReactivateAppParams{
+		AppID:           a.AppID,
+		UpdateAppID:     adt.App.ID,
+		UpdateUserID:    datastore.NewNullUUID(adt.User.ID),
+		UpdateTimestamp: adt.Moment,
+	}
+
+	rowsAffected, err := appstore.New(tx).ReactivateApp(ctx, reactivateParams)
+	if err != nil {
+		return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
+	}
+	if rowsAffected != 1 {
+		return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, fmt.Sprintf("rows affected should be 1, actual: %d", rowsAffected)))
+	}
+
+	if _, err = appstore.New(tx).ReactivateAppAPIKeys(ctx, appstore.ReactivateAppAPIKeysParams{
+		AppID:           a.AppID,
+		UpdateAppID:     adt.App.ID,
+		UpdateUserID:    datastore.NewNullUUID(adt.User.ID),
+		UpdateTimestamp: adt.Moment,
+	}); err != nil {
+		return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
+	}
+
+	// Fetch Labels once, before the event is recorded, so the durable
+	// audit record and the post-commit webhook publish below describe
+	// the exact same snapshot.
+	labelRows, err := appstore.New(tx).FindAppLabelsByAppID(ctx, a.AppID)
+	if err != nil {
+		return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
+	}
+	snapshot := appSnapshot{Name: a.AppName, Description: a.AppDescription, Labels: labelsFromRows(labelRows)}
+
+	if err = s.recordAppEvent(ctx, tx, audit.AppReactivated, snapshot, adt); err != nil {
+		return AppResponse{}, s.Datastorer.RollbackTx(ctx, tx, err)
+	}
+
+	if err = s.Datastorer.CommitTx(ctx, tx); err != nil {
+		return AppResponse{}, err
+	}
+
+	aa, err := findAppByExternalIDWithAudit(ctx, s.Datastorer.Pool(), extlID)
+	if err != nil {
+		return AppResponse{}, errs.E(errs.Database, err)
+	}
+
+	s.publish(ctx, aa.App.Org.ID, audit.AppReactivated, snapshot)
+
+	return newAppResponse(aa), nil
+}
+
+// PurgeDeactivated permanently deletes every App (and its API keys)
+// that has been deactivated for longer than olderThan. Unlike Delete,
+// this is irreversible and intended for admin-driven compliance
+// cleanup, not ordinary request handling.
+func (s AppService) PurgeDeactivated(ctx context.Context, olderThan time.Duration) (int64, error) {
+	var tx pgx.Tx
+	tx, err := s.Datastorer.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := appstore.New(tx).PurgeDeactivatedApps(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, s.Datastorer.RollbackTx(ctx, tx, errs.E(errs.Database, err))
+	}
+
+	if err = s.Datastorer.CommitTx(ctx, tx); err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
+
+// findAppByExternalID retrieves an active (non-deactivated) App. The
+// underlying query excludes deactivated rows; use
+// FindAppByExternalIDIncludeDeactivated (see Reactivate) to look up a
+// soft-deleted App.
 func findAppByExternalID(ctx context.Context, dbtx DBTX, extlID string) (app.App, error) {
 	row, err := appstore.New(dbtx).FindAppByExternalID(ctx, extlID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return app.App{}, err
+		}
+		return app.App{}, errs.E(errs.Database, err)
+	}
+
+	labelRows, err := appstore.New(dbtx).FindAppLabelsByAppID(ctx, row.AppID)
 	if err != nil {
 		return app.App{}, errs.E(errs.Database, err)
 	}
@@ -320,6 +1111,7 @@ func findAppByExternalID(ctx context.Context, dbtx DBTX, extlID string) (app.App
 		Name:        row.AppName,
 		Description: row.AppDescription,
 		APIKeys:     nil,
+		Labels:      labelsFromRows(labelRows),
 	}
 
 	return a, nil
@@ -335,6 +1127,9 @@ func findAppByExternalIDWithAudit(ctx context.Context, dbtx DBTX, extlID string)
 
 	row, err = appstore.New(dbtx).FindAppByExternalIDWithAudit(ctx, extlID)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return appAudit{}, err
+		}
 		return appAudit{}, errs.E(errs.Database, err)
 	}
 
@@ -355,6 +1150,7 @@ func findAppByExternalIDWithAudit(ctx context.Context, dbtx DBTX, extlID string)
 		Name:        row.AppName,
 		Description: row.AppDescription,
 		APIKeys:     nil,
+		Labels:      labelsFromRows(row.Labels),
 	}
 
 	sa := audit.SimpleAudit{
@@ -402,3 +1198,80 @@ func findAppByExternalIDWithAudit(ctx context.Context, dbtx DBTX, extlID string)
 
 	return appAudit{App: a, SimpleAudit: sa}, nil
 }
+
+// SubscriptionRequest is the request struct for registering a webhook
+// Subscription.
+type SubscriptionRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+// SubscriptionResponse is the response struct for a webhook
+// Subscription. Secret is never echoed back.
+type SubscriptionResponse struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// CreateSubscription registers a new webhook Subscription for the
+// requesting Org.
+func (s AppService) CreateSubscription(ctx context.Context, r *SubscriptionRequest, adt audit.Audit) (SubscriptionResponse, error) {
+	id := uuid.New()
+
+	rowsAffected, err := notifystore.New(s.Datastorer.Pool()).CreateAppEventSubscription(ctx, notifystore.CreateAppEventSubscriptionParams{
+		SubscriptionID: id,
+		OrgID:          adt.App.Org.ID,
+		URL:            r.URL,
+		Secret:         r.Secret,
+		EventMask:      r.Events,
+	})
+	if err != nil {
+		return SubscriptionResponse{}, errs.E(errs.Database, err)
+	}
+	if rowsAffected != 1 {
+		return SubscriptionResponse{}, errs.E(errs.Database, fmt.Sprintf("rows affected should be 1, actual: %d", rowsAffected))
+	}
+
+	return SubscriptionResponse{ID: id.String(), URL: r.URL, Events: r.Events}, nil
+}
+
+// DeleteSubscription removes a webhook Subscription belonging to the
+// requesting Org.
+func (s AppService) DeleteSubscription(ctx context.Context, subscriptionID string, adt audit.Audit) error {
+	id, err := uuid.Parse(subscriptionID)
+	if err != nil {
+		return errs.E(errs.Validation, "subscription_id must be a valid UUID")
+	}
+
+	rowsAffected, err := notifystore.New(s.Datastorer.Pool()).DeleteAppEventSubscription(ctx, id, adt.App.Org.ID)
+	if err != nil {
+		return errs.E(errs.Database, err)
+	}
+	if rowsAffected != 1 {
+		return errs.E(errs.Validation, "No subscription exists for the given ID")
+	}
+
+	return nil
+}
+
+// ListSubscriptions returns every webhook Subscription registered for
+// the requesting Org.
+func (s AppService) ListSubscriptions(ctx context.Context, adt audit.Audit) ([]SubscriptionResponse, error) {
+	rows, err := notifystore.New(s.Datastorer.Pool()).FindAppEventSubscriptionsByOrgID(ctx, adt.App.Org.ID)
+	if err != nil {
+		return nil, errs.E(errs.Database, err)
+	}
+
+	var resp []SubscriptionResponse
+	for _, row := range rows {
+		resp = append(resp, SubscriptionResponse{
+			ID:     row.SubscriptionID.String(),
+			URL:    row.URL,
+			Events: strings.Split(row.EventMask, ","),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,216 @@
+// Package webhook implements domain/notify.Gateway by delivering signed
+// JSON payloads to subscriber-provided URLs over HTTP, off of the
+// caller's goroutine.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gilcrest/go-api-basic/datastore/notifystore"
+	"github.com/gilcrest/go-api-basic/domain/audit"
+	"github.com/gilcrest/go-api-basic/domain/notify"
+)
+
+// delivery is a single attempt to deliver an event to a subscriber.
+type delivery struct {
+	subscription notifystore.AppEventSubscriptionRow
+	eventType    audit.EventType
+	body         []byte
+	attempt      int
+}
+
+// Gateway is a notify.Gateway that delivers events to subscribers via a
+// bounded pool of worker goroutines, retrying failed deliveries with
+// exponential backoff before recording them as dead letters.
+type Gateway struct {
+	Subscriptions *notifystore.Queries
+	DeadLetters   *notifystore.Queries
+	Client        *http.Client
+	Logger        *slog.Logger
+
+	// MaxAttempts is the number of delivery attempts (including the
+	// first) before a delivery is dead-lettered.
+	MaxAttempts int
+
+	queue  chan delivery
+	cancel context.CancelFunc
+}
+
+// NewGateway starts a Gateway with workers background goroutines pulling
+// from a bounded delivery queue. Call Shutdown to stop the workers; it
+// cancels in-flight deliveries rather than waiting for them.
+func NewGateway(subscriptions, deadLetters *notifystore.Queries, logger *slog.Logger, workers, maxAttempts int) *Gateway {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	g := &Gateway{
+		Subscriptions: subscriptions,
+		DeadLetters:   deadLetters,
+		Client:        &http.Client{Timeout: 10 * time.Second},
+		Logger:        logger,
+		MaxAttempts:   maxAttempts,
+		queue:         make(chan delivery, 256),
+		cancel:        cancel,
+	}
+
+	for i := 0; i < workers; i++ {
+		go g.worker(ctx)
+	}
+
+	return g
+}
+
+// Shutdown cancels in-flight delivery attempts and stops the worker
+// pool. Deliveries still queued are dropped.
+func (g *Gateway) Shutdown() {
+	g.cancel()
+}
+
+// Publish looks up every Subscription registered for orgID and evtType
+// and enqueues a delivery for each. It never blocks on delivery; a full
+// queue drops the event rather than stall the caller.
+func (g *Gateway) Publish(ctx context.Context, orgID uuid.UUID, evtType audit.EventType, payload interface{}) {
+	rows, err := g.Subscriptions.FindAppEventSubscriptionsByOrgID(ctx, orgID)
+	if err != nil {
+		g.Logger.Error("find webhook subscriptions", "error", err)
+		return
+	}
+
+	body, err := json.Marshal(struct {
+		Type    audit.EventType `json:"type"`
+		Payload interface{}     `json:"payload"`
+	}{Type: evtType, Payload: payload})
+	if err != nil {
+		g.Logger.Error("marshal webhook payload", "error", err)
+		return
+	}
+
+	for _, row := range rows {
+		sub := notify.Subscription{
+			ID:     row.SubscriptionID,
+			OrgID:  row.OrgID,
+			URL:    row.URL,
+			Secret: row.Secret,
+			Events: parseEventMask(row.EventMask),
+		}
+		if !sub.Wants(evtType) {
+			continue
+		}
+
+		d := delivery{subscription: row, eventType: evtType, body: body, attempt: 1}
+
+		select {
+		case g.queue <- d:
+		default:
+			g.Logger.Warn("webhook delivery queue full, dropping event",
+				"subscription_id", row.SubscriptionID, "event_type", evtType)
+		}
+	}
+}
+
+// parseEventMask splits a comma-joined event_mask column back into its
+// individual event types, as stored by notifystore.CreateAppEventSubscription.
+func parseEventMask(mask string) []audit.EventType {
+	parts := strings.Split(mask, ",")
+	events := make([]audit.EventType, len(parts))
+	for i, p := range parts {
+		events[i] = audit.EventType(p)
+	}
+	return events
+}
+
+func (g *Gateway) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-g.queue:
+			g.attempt(ctx, d)
+		}
+	}
+}
+
+func (g *Gateway) attempt(ctx context.Context, d delivery) {
+	err := g.deliver(ctx, d)
+	if err == nil {
+		return
+	}
+
+	if d.attempt >= g.MaxAttempts {
+		g.deadLetter(ctx, d, err)
+		return
+	}
+
+	backoff := time.Duration(1<<uint(d.attempt)) * time.Second
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C:
+		d.attempt++
+		g.attempt(ctx, d)
+	}
+}
+
+func (g *Gateway) deliver(ctx context.Context, d delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.subscription.URL, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature", sign(d.subscription.Secret, d.body))
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &deliveryError{statusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+func (g *Gateway) deadLetter(ctx context.Context, d delivery, lastErr error) {
+	_, err := g.DeadLetters.CreateAppEventDeadLetter(ctx, notifystore.CreateAppEventDeadLetterParams{
+		DeadLetterID:   uuid.New(),
+		SubscriptionID: d.subscription.SubscriptionID,
+		EventType:      string(d.eventType),
+		Payload:        d.body,
+		LastError:      lastErr.Error(),
+		Attempts:       int32(d.attempt),
+		Moment:         time.Now(),
+	})
+	if err != nil {
+		g.Logger.Error("record webhook dead letter", "error", err, "subscription_id", d.subscription.SubscriptionID)
+	}
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of body using secret.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type deliveryError struct {
+	statusCode int
+}
+
+func (e *deliveryError) Error() string {
+	return "webhook delivery failed with status " + http.StatusText(e.statusCode)
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	secret := "shh-its-a-secret"
+	body := []byte(`{"type":"app.created","payload":{}}`)
+
+	got := sign(secret, body)
+	want := sign(secret, body)
+	if got != want {
+		t.Fatalf("sign is not deterministic: %q != %q", got, want)
+	}
+	if len(got) != 64 {
+		t.Errorf("sign() returned %d hex chars, want 64 (SHA-256)", len(got))
+	}
+
+	if sign("different-secret", body) == got {
+		t.Error("sign() should differ when the secret changes")
+	}
+	if sign(secret, []byte("different body")) == got {
+		t.Error("sign() should differ when the body changes")
+	}
+}
+
+func TestParseEventMask(t *testing.T) {
+	tests := []struct {
+		name string
+		mask string
+		want []string
+	}{
+		{name: "single", mask: "app.created", want: []string{"app.created"}},
+		{name: "multiple", mask: "app.created,app.deleted", want: []string{"app.created", "app.deleted"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseEventMask(tt.mask)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseEventMask(%q) = %v, want %v", tt.mask, got, tt.want)
+			}
+			for i, w := range tt.want {
+				if string(got[i]) != w {
+					t.Errorf("parseEventMask(%q)[%d] = %q, want %q", tt.mask, i, got[i], w)
+				}
+			}
+		})
+	}
+}